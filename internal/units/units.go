@@ -0,0 +1,114 @@
+// Package units converts between byte counts and the human-readable size strings ("8G",
+// "512MiB") used in config files and CLI input throughout this module.
+package units
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// Byte-size constants, in bytes. IEC (Kibibyte, Mebibyte, ...) and SI (Kilobyte, Megabyte, ...)
+// multiples are both provided since Proxmox and its callers mix the two conventions.
+const (
+	Byte = 1
+
+	Kibibyte = 1024 * Byte
+	Mebibyte = 1024 * Kibibyte
+	Gibibyte = 1024 * Mebibyte
+	Tebibyte = 1024 * Gibibyte
+	Pebibyte = 1024 * Tebibyte
+
+	Kilobyte = 1000 * Byte
+	Megabyte = 1000 * Kilobyte
+	Gigabyte = 1000 * Megabyte
+	Terabyte = 1000 * Gigabyte
+	Petabyte = 1000 * Terabyte
+)
+
+const (
+	Error_Empty    = "size must not be empty"
+	Error_Negative = "size must not be negative"
+	Error_Zero     = "size must be greater than zero"
+	Error_Invalid  = "invalid size"
+)
+
+// unitSuffixes maps a (case-folded) size suffix to its byte factor. Bare K/M/G/T/P and their
+// explicit IEC spelling (KiB, MiB, ...) are binary (1024-based), matching Proxmox's GiB
+// convention for disk sizes; only the explicit SI spelling (KB, MB, ...) is decimal
+// (1000-based). This keeps "8G" and "8GiB" equivalent and round-tripping with FormatByteSize,
+// which only ever emits the IEC form.
+var unitSuffixes = map[string]int64{
+	"":    Byte,
+	"B":   Byte,
+	"K":   Kibibyte,
+	"KIB": Kibibyte,
+	"KB":  Kilobyte,
+	"M":   Mebibyte,
+	"MIB": Mebibyte,
+	"MB":  Megabyte,
+	"G":   Gibibyte,
+	"GIB": Gibibyte,
+	"GB":  Gigabyte,
+	"T":   Tebibyte,
+	"TIB": Tebibyte,
+	"TB":  Terabyte,
+	"P":   Pebibyte,
+	"PIB": Pebibyte,
+	"PB":  Petabyte,
+}
+
+// ParseByteSize parses a human-readable size such as "8G" or "512MiB" into a byte count. Bare
+// K/M/G/T/P and explicit IEC suffixes (KiB, MiB, GiB, TiB, PiB) are binary; explicit SI
+// suffixes (KB, MB, GB, TB, PB) are decimal. Suffixes are accepted case insensitively, and a
+// bare number is treated as a raw byte count. Negative and zero sizes are rejected.
+func ParseByteSize(raw string) (int64, error) {
+	trimmed := strings.TrimSpace(raw)
+	if trimmed == "" {
+		return 0, errors.New(Error_Empty)
+	}
+	cut := len(trimmed)
+	for cut > 0 && !isDigit(trimmed[cut-1]) {
+		cut--
+	}
+	numberPart := trimmed[:cut]
+	suffix := strings.ToUpper(strings.TrimSpace(trimmed[cut:]))
+	factor, isSet := unitSuffixes[suffix]
+	if !isSet {
+		return 0, errors.New(Error_Invalid)
+	}
+	value, err := strconv.ParseFloat(numberPart, 64)
+	if err != nil {
+		return 0, errors.New(Error_Invalid)
+	}
+	if value < 0 {
+		return 0, errors.New(Error_Negative)
+	}
+	if value == 0 {
+		return 0, errors.New(Error_Zero)
+	}
+	return int64(value * float64(factor)), nil
+}
+
+// FormatByteSize formats a byte count using the largest IEC unit that divides it evenly,
+// falling back to a plain byte count for sizes smaller than 1 KiB.
+func FormatByteSize(size int64) string {
+	units := []struct {
+		suffix string
+		factor int64
+	}{
+		{"PiB", Pebibyte},
+		{"TiB", Tebibyte},
+		{"GiB", Gibibyte},
+		{"MiB", Mebibyte},
+		{"KiB", Kibibyte},
+	}
+	for _, unit := range units {
+		if size >= unit.factor && size%unit.factor == 0 {
+			return strconv.FormatInt(size/unit.factor, 10) + unit.suffix
+		}
+	}
+	return strconv.FormatInt(size, 10) + "B"
+}
+
+func isDigit(b byte) bool { return b >= '0' && b <= '9' }
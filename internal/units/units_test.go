@@ -0,0 +1,91 @@
+package units
+
+import (
+	"strconv"
+	"testing"
+)
+
+func TestParseByteSize(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    int64
+		wantErr string
+	}{
+		{name: "bare K is binary", input: "8K", want: 8 * Kibibyte},
+		{name: "bare M is binary", input: "8M", want: 8 * Mebibyte},
+		{name: "bare G is binary", input: "8G", want: 8 * Gibibyte},
+		{name: "bare T is binary", input: "2T", want: 2 * Tebibyte},
+		{name: "bare P is binary", input: "1P", want: Pebibyte},
+		{name: "explicit IEC suffix", input: "8GiB", want: 8 * Gibibyte},
+		{name: "explicit IEC suffix is case insensitive", input: "8gib", want: 8 * Gibibyte},
+		{name: "explicit SI suffix is decimal", input: "8GB", want: 8 * Gigabyte},
+		{name: "explicit SI suffix, kilo", input: "8KB", want: 8 * Kilobyte},
+		{name: "no suffix is a raw byte count", input: "131072", want: 131072},
+		{name: "bare byte suffix", input: "512B", want: 512},
+		{name: "fractional size is truncated to a whole byte", input: "1.5G", want: int64(1.5 * float64(Gibibyte))},
+		{name: "surrounding whitespace is trimmed", input: " 8G ", want: 8 * Gibibyte},
+		{name: "empty is rejected", input: "", wantErr: Error_Empty},
+		{name: "whitespace only is rejected", input: "   ", wantErr: Error_Empty},
+		{name: "negative is rejected", input: "-8G", wantErr: Error_Negative},
+		{name: "zero is rejected", input: "0G", wantErr: Error_Zero},
+		{name: "zero with no suffix is rejected", input: "0", wantErr: Error_Zero},
+		{name: "unknown suffix is rejected", input: "8X", wantErr: Error_Invalid},
+		{name: "garbage number is rejected", input: "abc", wantErr: Error_Invalid},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseByteSize(tt.input)
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("ParseByteSize(%q) error = %v, want %q", tt.input, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseByteSize(%q) unexpected error: %v", tt.input, err)
+			}
+			if got != tt.want {
+				t.Fatalf("ParseByteSize(%q) = %d, want %d", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatByteSize(t *testing.T) {
+	tests := []struct {
+		name  string
+		input int64
+		want  string
+	}{
+		{name: "whole gibibyte", input: 8 * Gibibyte, want: "8GiB"},
+		{name: "whole mebibyte", input: 512 * Mebibyte, want: "512MiB"},
+		{name: "prefers the largest unit that divides evenly", input: 2 * Tebibyte, want: "2TiB"},
+		{name: "falls back to bytes when not a clean multiple", input: Gibibyte + 1, want: strconv.FormatInt(Gibibyte+1, 10) + "B"},
+		{name: "sub-kibibyte size stays in bytes", input: 512, want: "512B"},
+		{name: "zero", input: 0, want: "0B"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FormatByteSize(tt.input); got != tt.want {
+				t.Fatalf("FormatByteSize(%d) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestParseByteSize_FormatByteSize_RoundTrip checks that every IEC string FormatByteSize emits
+// parses back to the same byte count, so a round trip through Marshal/Unmarshal keeps the size.
+func TestParseByteSize_FormatByteSize_RoundTrip(t *testing.T) {
+	sizes := []int64{Kibibyte, Mebibyte, 8 * Gibibyte, 2 * Tebibyte, Pebibyte}
+	for _, size := range sizes {
+		formatted := FormatByteSize(size)
+		parsed, err := ParseByteSize(formatted)
+		if err != nil {
+			t.Fatalf("ParseByteSize(%q) unexpected error: %v", formatted, err)
+		}
+		if parsed != size {
+			t.Fatalf("round trip of %d through %q produced %d", size, formatted, parsed)
+		}
+	}
+}
@@ -2,14 +2,17 @@ package proxmox
 
 import (
 	"errors"
+	"path/filepath"
 	"strconv"
 	"strings"
 
+	"github.com/Telmate/proxmox-api-go/internal/units"
 	"github.com/Telmate/proxmox-api-go/internal/util"
 )
 
 type LxcBootMount struct {
 	ACL             *TriBool
+	HostPath        *string // reserved for future bind-root support, validated but not yet wired into string()/mapToApiCreate()
 	Options         *LxcBootMountOptions
 	Replication     *bool
 	SizeInKibibytes *LxcMountSize
@@ -29,21 +32,7 @@ func (mount LxcBootMount) combine(usedConfig LxcBootMount) LxcBootMount {
 		usedConfig.SizeInKibibytes = mount.SizeInKibibytes
 	}
 	if mount.Options != nil {
-		if usedConfig.Options == nil {
-			usedConfig.Options = &LxcBootMountOptions{}
-		}
-		if mount.Options.Discard != nil {
-			usedConfig.Options.Discard = mount.Options.Discard
-		}
-		if mount.Options.LazyTime != nil {
-			usedConfig.Options.LazyTime = mount.Options.LazyTime
-		}
-		if mount.Options.NoATime != nil {
-			usedConfig.Options.NoATime = mount.Options.NoATime
-		}
-		if mount.Options.NoSuid != nil {
-			usedConfig.Options.NoSuid = mount.Options.NoSuid
-		}
+		usedConfig.Options = usedConfig.Options.combine(mount.Options)
 	}
 	if mount.Replication != nil {
 		usedConfig.Replication = mount.Replication
@@ -57,13 +46,7 @@ func (mount LxcBootMount) combine(usedConfig LxcBootMount) LxcBootMount {
 func (config LxcBootMount) mapToApiCreate() string {
 	rootFs := config.string()
 	if config.Storage != nil && config.SizeInKibibytes != nil {
-		var size float64
-		if *config.SizeInKibibytes < gibiByteLxc { // only approximate if the size is less than 1 GiB
-			size = approximateDiskSize(int64(*config.SizeInKibibytes))
-		} else {
-			size = float64(*config.SizeInKibibytes / gibiByteLxc)
-		}
-		rootFs = *config.Storage + ":" + strconv.FormatFloat(size, 'f', -1, 64)
+		rootFs = *config.Storage + ":" + config.SizeInKibibytes.gibiByteString()
 	}
 	return rootFs
 }
@@ -82,6 +65,35 @@ func (config LxcBootMount) mapToApiUpdate_Unsafe(current *LxcBootMount, params m
 	params[lxcApiKeyRootFS] = rootFs
 }
 
+// Diff compares config against current and reports whether applying config on top of it would
+// produce a semantically different rootfs, returning the PUT parameters to send when it does.
+// Fields config leaves unset inherit whatever current already has; option pairs follow the same
+// override/cancel precedence as combine(), so an explicit config.Options.Suid always wins over
+// an inherited current.Options.NoSuid rather than the two being merged.
+func (config LxcBootMount) Diff(current LxcBootMount) (changed bool, params map[string]any, err error) {
+	if err = config.Validate(&current); err != nil {
+		return false, nil, err
+	}
+	var usedConfig LxcBootMount
+	usedConfig = config.combine(current.combine(usedConfig))
+	rootFs := usedConfig.string()
+	currentRootFs := current.string()
+	if usedConfig.Storage != nil {
+		rootFs = *usedConfig.Storage + ":" + current.rawDisk + rootFs
+	} else {
+		rootFs = current.rawDisk + rootFs
+	}
+	if current.Storage != nil {
+		currentRootFs = *current.Storage + ":" + current.rawDisk + currentRootFs
+	} else {
+		currentRootFs = current.rawDisk + currentRootFs
+	}
+	if rootFs == currentRootFs {
+		return false, map[string]any{}, nil
+	}
+	return true, map[string]any{lxcApiKeyRootFS: rootFs}, nil
+}
+
 func (config LxcBootMount) string() (rootFs string) {
 	// zfs  // local-zfs:subvol-101-disk-0
 	// ext4 // local-ext4:101/vm-101-disk-0.raw
@@ -95,21 +107,8 @@ func (config LxcBootMount) string() (rootFs string) {
 		}
 	}
 	if config.Options != nil {
-		var options string
-		if config.Options.Discard != nil && *config.Options.Discard {
-			options += ";discard"
-		}
-		if config.Options.LazyTime != nil && *config.Options.LazyTime {
-			options += ";lazytime"
-		}
-		if config.Options.NoATime != nil && *config.Options.NoATime {
-			options += ";noatime"
-		}
-		if config.Options.NoSuid != nil && *config.Options.NoSuid {
-			options += ";nosuid"
-		}
-		if options != "" {
-			rootFs += ",mountoptions=" + options[1:]
+		if options := config.Options.string(); options != "" {
+			rootFs += ",mountoptions=" + options
 		}
 	}
 	if config.Replication != nil && !*config.Replication {
@@ -128,25 +127,151 @@ func (config LxcBootMount) Validate(current *LxcBootMount) error {
 	if current == nil && config.Storage == nil {
 		return errors.New(LxcBootMount_Error_NoStorageDuringCreation)
 	}
+	if config.HostPath != nil {
+		if err = ValidateVolumeHostDir(*config.HostPath); err != nil {
+			return err
+		}
+	}
 	if config.SizeInKibibytes != nil {
 		err = config.SizeInKibibytes.Validate()
 	}
 	return err
 }
 
+// LxcBootMountOptions holds the Linux mount flags Proxmox accepts for a rootfs or mpN mount
+// point. Fields that mirror a mount(8) option pair (Exec/NoExec, Dev/NoDev, Suid/NoSuid,
+// RelATime/StrictATime) are tri-state: nil inherits whatever is already persisted, while an
+// explicit true or false always wins, so setting e.g. Exec=true on an update clears a
+// previously-persisted noexec rather than merging with it. Exec, Dev and Suid emit both their
+// on and off forms; NoSuid, RelATime and StrictATime are emitted only when true.
 type LxcBootMountOptions struct {
-	Discard  *bool
-	LazyTime *bool
-	NoATime  *bool
-	NoSuid   *bool
+	Async       *bool
+	Dev         *bool
+	DirSync     *bool
+	Discard     *bool
+	Exec        *bool
+	LazyTime    *bool
+	NoATime     *bool
+	NoSuid      *bool
+	RelATime    *bool
+	StrictATime *bool
+	Suid        *bool
+	Sync        *bool
+}
+
+// combine returns a copy of current with every field options explicitly sets applied on top.
+// Setting one side of an option pair clears whatever is held for its counterpart, so the two
+// can never both be emitted by string().
+func (current *LxcBootMountOptions) combine(options *LxcBootMountOptions) *LxcBootMountOptions {
+	if options == nil {
+		return current
+	}
+	var usedOptions LxcBootMountOptions
+	if current != nil {
+		usedOptions = *current
+	}
+	if options.Discard != nil {
+		usedOptions.Discard = options.Discard
+	}
+	if options.LazyTime != nil {
+		usedOptions.LazyTime = options.LazyTime
+	}
+	if options.NoATime != nil {
+		usedOptions.NoATime = options.NoATime
+	}
+	if options.NoSuid != nil {
+		usedOptions.NoSuid = options.NoSuid
+		usedOptions.Suid = nil
+	}
+	if options.Async != nil {
+		usedOptions.Async = options.Async
+	}
+	if options.Dev != nil {
+		usedOptions.Dev = options.Dev
+	}
+	if options.DirSync != nil {
+		usedOptions.DirSync = options.DirSync
+	}
+	if options.Exec != nil {
+		usedOptions.Exec = options.Exec
+	}
+	if options.RelATime != nil {
+		usedOptions.RelATime = options.RelATime
+		usedOptions.StrictATime = nil
+	}
+	if options.StrictATime != nil {
+		usedOptions.StrictATime = options.StrictATime
+		usedOptions.RelATime = nil
+	}
+	if options.Suid != nil {
+		usedOptions.Suid = options.Suid
+		usedOptions.NoSuid = nil
+	}
+	if options.Sync != nil {
+		usedOptions.Sync = options.Sync
+	}
+	return &usedOptions
+}
+
+func (options LxcBootMountOptions) string() string {
+	var parts []string
+	if options.Discard != nil && *options.Discard {
+		parts = append(parts, "discard")
+	}
+	if options.LazyTime != nil && *options.LazyTime {
+		parts = append(parts, "lazytime")
+	}
+	if options.NoATime != nil && *options.NoATime {
+		parts = append(parts, "noatime")
+	}
+	if options.NoSuid != nil && *options.NoSuid {
+		parts = append(parts, "nosuid")
+	}
+	if options.Async != nil && *options.Async {
+		parts = append(parts, "async")
+	}
+	if options.Dev != nil {
+		if *options.Dev {
+			parts = append(parts, "dev")
+		} else {
+			parts = append(parts, "nodev")
+		}
+	}
+	if options.DirSync != nil && *options.DirSync {
+		parts = append(parts, "dirsync")
+	}
+	if options.Exec != nil {
+		if *options.Exec {
+			parts = append(parts, "exec")
+		} else {
+			parts = append(parts, "noexec")
+		}
+	}
+	if options.RelATime != nil && *options.RelATime {
+		parts = append(parts, "relatime")
+	}
+	if options.StrictATime != nil && *options.StrictATime {
+		parts = append(parts, "strictatime")
+	}
+	if options.Suid != nil {
+		if *options.Suid {
+			parts = append(parts, "suid")
+		} else {
+			parts = append(parts, "nosuid")
+		}
+	}
+	if options.Sync != nil && *options.Sync {
+		parts = append(parts, "sync")
+	}
+	return strings.Join(parts, ";")
 }
 
+// LxcMountSize is a mount point size in kibibytes.
 type LxcMountSize uint
 
 const (
 	LxcMountSize_Error_Minimum = "mount point size must be greater than 131071"
 	lxcMountSize_Minimum       = LxcMountSize(gibiByteOneEighth)
-	gibiByteLxc                = mebiByte * 1024
 )
 
 func (size LxcMountSize) String() string { return strconv.Itoa(int(size)) } // String is for fmt.Stringer.
@@ -158,6 +283,54 @@ func (size LxcMountSize) Validate() error {
 	return nil
 }
 
+// LxcMountSizeFromString parses a human-readable size such as "8G" or "512MiB" into an
+// LxcMountSize. See internal/units.ParseByteSize for the accepted suffixes. A bare whole number
+// with no unit suffix is interpreted directly as a kibibyte count, matching the raw kibibyte
+// value this type wraps, rather than being run through ParseByteSize's byte-oriented
+// interpretation; a bare fractional number is passed to ParseByteSize and read as bytes.
+func LxcMountSizeFromString(raw string) (LxcMountSize, error) {
+	if trimmed := strings.TrimSpace(raw); trimmed != "" {
+		if value, err := strconv.ParseUint(trimmed, 10, 64); err == nil {
+			if value == 0 {
+				return 0, errors.New(units.Error_Zero)
+			}
+			return LxcMountSize(value), nil
+		}
+	}
+	bytes, err := units.ParseByteSize(raw)
+	if err != nil {
+		return 0, err
+	}
+	return LxcMountSize(bytes / units.Kibibyte), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, so config files can specify mount sizes
+// such as "8G" instead of a raw kibibyte count.
+func (size *LxcMountSize) UnmarshalText(text []byte) error {
+	parsed, err := LxcMountSizeFromString(string(text))
+	if err != nil {
+		return err
+	}
+	*size = parsed
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (size LxcMountSize) MarshalText() ([]byte, error) {
+	return []byte(units.FormatByteSize(int64(size) * units.Kibibyte)), nil
+}
+
+// gibiByteString formats the size the way Proxmox expects when creating a new disk: a GiB
+// value, falling back to an approximation for sizes below 1 GiB so a value doesn't round down
+// to "0".
+func (size LxcMountSize) gibiByteString() string {
+	bytes := int64(size) * units.Kibibyte
+	if bytes < units.Gibibyte {
+		return strconv.FormatFloat(approximateDiskSize(int64(size)), 'f', -1, 64)
+	}
+	return strconv.FormatFloat(float64(bytes)/float64(units.Gibibyte), 'f', -1, 64)
+}
+
 func (raw RawConfigLXC) BootMount() *LxcBootMount {
 	var config LxcBootMount
 	var settings map[string]string
@@ -185,33 +358,7 @@ func (raw RawConfigLXC) BootMount() *LxcBootMount {
 		config.ACL = util.Pointer(TriBoolNone)
 	}
 	if v, isSet := settings["mountoptions"]; isSet {
-		tmpOptions := strings.Split(v, ";")
-		options := make(map[string]struct{}, len(tmpOptions))
-		for i := 0; i < len(tmpOptions); i++ {
-			options[tmpOptions[i]] = struct{}{}
-		}
-		var mountOptions LxcBootMountOptions
-		if _, isSet := options["discard"]; isSet {
-			mountOptions.Discard = util.Pointer(true)
-		} else {
-			mountOptions.Discard = util.Pointer(false)
-		}
-		if _, isSet := options["lazytime"]; isSet {
-			mountOptions.LazyTime = util.Pointer(true)
-		} else {
-			mountOptions.LazyTime = util.Pointer(false)
-		}
-		if _, isSet := options["noatime"]; isSet {
-			mountOptions.NoATime = util.Pointer(true)
-		} else {
-			mountOptions.NoATime = util.Pointer(false)
-		}
-		if _, isSet := options["nosuid"]; isSet {
-			mountOptions.NoSuid = util.Pointer(true)
-		} else {
-			mountOptions.NoSuid = util.Pointer(false)
-		}
-		config.Options = &mountOptions
+		config.Options = parseLxcBootMountOptions(v)
 	}
 	if v, isSet := settings["replicate"]; isSet {
 		config.Replication = util.Pointer(v == "1")
@@ -220,3 +367,126 @@ func (raw RawConfigLXC) BootMount() *LxcBootMount {
 	}
 	return &config
 }
+
+// parseLxcBootMountOptions splits the semicolon-separated `mountoptions` value Proxmox stores
+// on rootfs and mpN mount points into a LxcBootMountOptions. It is shared by BootMount() and
+// RawConfigLXC.MountPoints() so the two mount kinds can never drift in how they interpret the
+// same option string.
+func parseLxcBootMountOptions(raw string) *LxcBootMountOptions {
+	tmpOptions := strings.Split(raw, ";")
+	options := make(map[string]struct{}, len(tmpOptions))
+	for i := range tmpOptions {
+		options[tmpOptions[i]] = struct{}{}
+	}
+	var mountOptions LxcBootMountOptions
+	if _, isSet := options["discard"]; isSet {
+		mountOptions.Discard = util.Pointer(true)
+	} else {
+		mountOptions.Discard = util.Pointer(false)
+	}
+	if _, isSet := options["lazytime"]; isSet {
+		mountOptions.LazyTime = util.Pointer(true)
+	} else {
+		mountOptions.LazyTime = util.Pointer(false)
+	}
+	if _, isSet := options["noatime"]; isSet {
+		mountOptions.NoATime = util.Pointer(true)
+	} else {
+		mountOptions.NoATime = util.Pointer(false)
+	}
+	if _, isSet := options["dirsync"]; isSet {
+		mountOptions.DirSync = util.Pointer(true)
+	} else {
+		mountOptions.DirSync = util.Pointer(false)
+	}
+	if _, isSet := options["suid"]; isSet {
+		mountOptions.Suid = util.Pointer(true)
+	} else if _, isSet := options["nosuid"]; isSet {
+		mountOptions.NoSuid = util.Pointer(true)
+	} else {
+		mountOptions.NoSuid = util.Pointer(false)
+	}
+	if _, isSet := options["exec"]; isSet {
+		mountOptions.Exec = util.Pointer(true)
+	} else if _, isSet := options["noexec"]; isSet {
+		mountOptions.Exec = util.Pointer(false)
+	}
+	if _, isSet := options["dev"]; isSet {
+		mountOptions.Dev = util.Pointer(true)
+	} else if _, isSet := options["nodev"]; isSet {
+		mountOptions.Dev = util.Pointer(false)
+	}
+	if _, isSet := options["relatime"]; isSet {
+		mountOptions.RelATime = util.Pointer(true)
+	} else if _, isSet := options["strictatime"]; isSet {
+		mountOptions.StrictATime = util.Pointer(true)
+	}
+	if _, isSet := options["sync"]; isSet {
+		mountOptions.Sync = util.Pointer(true)
+	} else if _, isSet := options["async"]; isSet {
+		mountOptions.Async = util.Pointer(true)
+	}
+	return &mountOptions
+}
+
+const (
+	ValidateVolumeHostDir_Error_Empty       = "host path must not be empty"
+	ValidateVolumeHostDir_Error_NotAbsolute = "host path must be absolute"
+	ValidateVolumeHostDir_Error_Managed     = "host path must not resolve inside a Proxmox-managed storage root"
+)
+
+// lxcManagedStorageRoots are host directories Proxmox itself manages; a bind mount that
+// resolves inside one of them could clobber state the hypervisor depends on.
+var lxcManagedStorageRoots = []string{"/var/lib/vz", "/etc/pve"}
+
+// ValidateVolumeHostDir checks that path is usable as the host side of an LXC bind mount: it
+// must be non-empty, absolute, and must not resolve (following any symlinks) inside a
+// Proxmox-managed storage root such as /var/lib/vz or /etc/pve. Symlink resolution is
+// best-effort, since the path may not exist yet on the host running this validation.
+func ValidateVolumeHostDir(path string) error {
+	if path == "" {
+		return errors.New(ValidateVolumeHostDir_Error_Empty)
+	}
+	if !filepath.IsAbs(path) {
+		return errors.New(ValidateVolumeHostDir_Error_NotAbsolute)
+	}
+	resolved, err := filepath.EvalSymlinks(path)
+	if err != nil {
+		resolved = filepath.Clean(path)
+	}
+	for _, root := range lxcManagedStorageRoots {
+		if resolved == root || strings.HasPrefix(resolved, root+string(filepath.Separator)) {
+			return errors.New(ValidateVolumeHostDir_Error_Managed)
+		}
+	}
+	return nil
+}
+
+const (
+	ValidateMountPropagation_Error_Invalid = "mount propagation must be one of shared, slave, or private"
+)
+
+// ValidateMountPropagation checks that propagation is one of the mount propagation flags
+// Proxmox exposes for mpN bind mounts via the `mountoptions` value, so an invalid value fails
+// client-side rather than surfacing as an opaque server error.
+func ValidateMountPropagation(propagation string) error {
+	switch propagation {
+	case "shared", "slave", "private":
+		return nil
+	default:
+		return errors.New(ValidateMountPropagation_Error_Invalid)
+	}
+}
+
+// parseLxcMountPropagation looks for a mount propagation flag among the semicolon-separated
+// tokens of a `mountoptions` value. There is no dedicated propagation key on the wire; the
+// flag travels alongside the other mount(8) options parseLxcBootMountOptions reads.
+func parseLxcMountPropagation(raw string) *string {
+	for _, token := range strings.Split(raw, ";") {
+		switch token {
+		case "shared", "slave", "private":
+			return util.Pointer(token)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,404 @@
+package proxmox
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Telmate/proxmox-api-go/internal/util"
+)
+
+// LxcMountPoints models the optional mpN (mp0..mp255) mount points a container can have, keyed
+// by their numeric suffix. Unlike the rootfs modeled by LxcBootMount, a mount point may also be
+// a bind mount or device passthrough of an absolute host path instead of a storage-backed volume.
+type LxcMountPoints map[uint8]LxcMountPoint
+
+const (
+	LxcMountPointCountMaximum = 256
+	lxcApiKeyMountPointPrefix = "mp"
+)
+
+func (config LxcMountPoints) mapToApiCreate(params map[string]any) {
+	for id, mount := range config {
+		if v := mount.mapToApiCreate(); v != "" {
+			params[lxcApiKeyMountPointPrefix+strconv.Itoa(int(id))] = v
+		}
+	}
+}
+
+func (config LxcMountPoints) mapToApiUpdate_Unsafe(current LxcMountPoints, params map[string]any) {
+	for id, mount := range config {
+		key := lxcApiKeyMountPointPrefix + strconv.Itoa(int(id))
+		if currentMount, isSet := current[id]; isSet {
+			mount.mapToApiUpdate_Unsafe(&currentMount, key, params)
+		} else if v := mount.mapToApiCreate(); v != "" {
+			params[key] = v
+		}
+	}
+}
+
+// Diff compares config against current across every mount point and reports whether any of
+// them would semantically change, returning the combined PUT parameters for the ones that did.
+// It runs the same Validate pass mapToApiUpdate_Unsafe skips, so callers can use it to preview
+// a plan before issuing the PUT.
+func (config LxcMountPoints) Diff(current LxcMountPoints) (changed bool, params map[string]any, err error) {
+	if err = config.Validate(current); err != nil {
+		return false, nil, err
+	}
+	params = make(map[string]any)
+	for id, mount := range config {
+		key := lxcApiKeyMountPointPrefix + strconv.Itoa(int(id))
+		currentMount, isSet := current[id]
+		if !isSet {
+			if v := mount.mapToApiCreate(); v != "" {
+				params[key] = v
+				changed = true
+			}
+			continue
+		}
+		if mountChanged, mountParams := mount.Diff(currentMount, key); mountChanged {
+			changed = true
+			for k, v := range mountParams {
+				params[k] = v
+			}
+		}
+	}
+	return changed, params, nil
+}
+
+const LxcMountPoints_Error_OverlappingHostPath = "mount points mp%d and mp%d have overlapping host paths"
+
+// Validate validates every mount point in config against its counterpart in current, then
+// checks the full effective set (config layered on top of current) for bind-mount host paths
+// that overlap one another, since Proxmox would otherwise bind the same host directory into
+// the container twice, or nest one bind mount inside another.
+func (config LxcMountPoints) Validate(current LxcMountPoints) error {
+	effective := make(map[uint8]LxcMountPoint, len(config)+len(current))
+	for id, mount := range current {
+		effective[id] = mount
+	}
+	for id, mount := range config {
+		var currentMount *LxcMountPoint
+		if c, isSet := current[id]; isSet {
+			currentMount = &c
+		}
+		if err := mount.Validate(currentMount); err != nil {
+			return err
+		}
+		if currentMount != nil {
+			effective[id] = mount.combine(*currentMount)
+		} else {
+			effective[id] = mount
+		}
+	}
+	return validateNoOverlappingHostPaths(effective)
+}
+
+// validateNoOverlappingHostPaths rejects a set of mount points where two or more bind-mount
+// HostPath entries are the same directory, or one is nested inside another.
+func validateNoOverlappingHostPaths(mounts map[uint8]LxcMountPoint) error {
+	ids := make([]uint8, 0, len(mounts))
+	for id, mount := range mounts {
+		if mount.HostPath != nil {
+			ids = append(ids, id)
+		}
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	for i := range ids {
+		for j := i + 1; j < len(ids); j++ {
+			if hostPathsOverlap(*mounts[ids[i]].HostPath, *mounts[ids[j]].HostPath) {
+				return fmt.Errorf(LxcMountPoints_Error_OverlappingHostPath, ids[i], ids[j])
+			}
+		}
+	}
+	return nil
+}
+
+// hostPathsOverlap reports whether a and b are the same directory, or one is nested inside the
+// other, after cleaning both paths.
+func hostPathsOverlap(a, b string) bool {
+	a, b = filepath.Clean(a), filepath.Clean(b)
+	if a == b {
+		return true
+	}
+	return strings.HasPrefix(a, b+string(filepath.Separator)) || strings.HasPrefix(b, a+string(filepath.Separator))
+}
+
+type LxcMountPoint struct {
+	ACL             *TriBool
+	Backup          *bool
+	HostPath        *string // absolute host path, used for bind mounts and device passthrough instead of Storage
+	MountPath       *string // Required during creation
+	Options         *LxcBootMountOptions
+	Propagation     *string // one of "shared", "slave", or "private", see ValidateMountPropagation; encoded as a mountoptions flag, not a standalone key
+	Quota           *bool
+	ReadOnly        *bool
+	Replication     *bool
+	Shared          *bool
+	SizeInKibibytes *LxcMountSize
+	Storage         *string // Required during creation, unless HostPath is set
+	rawVolume       string
+}
+
+const (
+	LxcMountPoint_Error_NoVolumeDuringCreation    = "storage or a host path must be set during creation"
+	LxcMountPoint_Error_NoMountPathDuringCreation = "mount path must be set during creation"
+	LxcMountPoint_Error_NoSizeDuringCreation      = "size must be set during creation when storage is set"
+	LxcMountPoint_Error_StorageAndHostPath        = "storage and host path are mutually exclusive"
+)
+
+func (mount LxcMountPoint) combine(usedConfig LxcMountPoint) LxcMountPoint {
+	if mount.ACL != nil {
+		usedConfig.ACL = mount.ACL
+	}
+	if mount.Backup != nil {
+		usedConfig.Backup = mount.Backup
+	}
+	if mount.HostPath != nil {
+		usedConfig.HostPath = mount.HostPath
+		usedConfig.Storage = nil
+		usedConfig.SizeInKibibytes = nil
+	}
+	if mount.MountPath != nil {
+		usedConfig.MountPath = mount.MountPath
+	}
+	if mount.Options != nil {
+		usedConfig.Options = usedConfig.Options.combine(mount.Options)
+	}
+	if mount.Propagation != nil {
+		usedConfig.Propagation = mount.Propagation
+	}
+	if mount.Quota != nil {
+		usedConfig.Quota = mount.Quota
+	}
+	if mount.ReadOnly != nil {
+		usedConfig.ReadOnly = mount.ReadOnly
+	}
+	if mount.Replication != nil {
+		usedConfig.Replication = mount.Replication
+	}
+	if mount.Shared != nil {
+		usedConfig.Shared = mount.Shared
+	}
+	if mount.SizeInKibibytes != nil {
+		usedConfig.SizeInKibibytes = mount.SizeInKibibytes
+		usedConfig.HostPath = nil
+	}
+	if mount.Storage != nil {
+		usedConfig.Storage = mount.Storage
+		usedConfig.HostPath = nil
+	}
+	return usedConfig
+}
+
+func (mount LxcMountPoint) mapToApiCreate() string {
+	settings := mount.string()
+	if mount.HostPath != nil {
+		return *mount.HostPath + settings
+	}
+	if mount.Storage != nil && mount.SizeInKibibytes != nil {
+		return *mount.Storage + ":" + mount.SizeInKibibytes.gibiByteString() + settings
+	}
+	return settings
+}
+
+func (mount LxcMountPoint) mapToApiUpdate_Unsafe(current *LxcMountPoint, key string, params map[string]any) {
+	var usedConfig LxcMountPoint
+	usedConfig = mount.combine(current.combine(usedConfig))
+	value, currentValue := usedConfig.volume(current.rawVolume), current.volume(current.rawVolume)
+	if value == currentValue {
+		return
+	}
+	params[key] = value
+}
+
+// Diff compares mount against current and reports whether applying mount on top of it would
+// produce a semantically different value for this mount point, returning the PUT parameter for
+// key when it does. Fields mount leaves unset inherit whatever current already has, the same
+// way mapToApiUpdate_Unsafe behaves; Diff only additionally makes that comparison inspectable
+// by callers instead of writing straight into params.
+func (mount LxcMountPoint) Diff(current LxcMountPoint, key string) (changed bool, params map[string]any) {
+	var usedConfig LxcMountPoint
+	usedConfig = mount.combine(current.combine(usedConfig))
+	value, currentValue := usedConfig.volume(current.rawVolume), current.volume(current.rawVolume)
+	if value == currentValue {
+		return false, map[string]any{}
+	}
+	return true, map[string]any{key: value}
+}
+
+// volume renders the mount point the way Proxmox expects on the wire: the storage/host-path
+// volume specifier followed by its comma-separated settings. rawVolume is the unparsed size
+// suffix recorded when this mount point was last read from the API, preserved verbatim since
+// updates don't need to resend it.
+func (mount LxcMountPoint) volume(rawVolume string) string {
+	settings := mount.string()
+	switch {
+	case mount.HostPath != nil:
+		return *mount.HostPath + settings
+	case mount.Storage != nil:
+		// we can ignore adding the size, the call will work without it
+		return *mount.Storage + ":" + rawVolume + settings
+	default:
+		return rawVolume + settings
+	}
+}
+
+func (mount LxcMountPoint) string() (settings string) {
+	if mount.ACL != nil {
+		switch *mount.ACL {
+		case TriBoolTrue:
+			settings += ",acl=1"
+		case TriBoolFalse:
+			settings += ",acl=0"
+		}
+	}
+	if mount.Backup != nil && *mount.Backup {
+		settings += ",backup=1"
+	}
+	if mount.MountPath != nil {
+		settings += ",mp=" + *mount.MountPath
+	}
+	// Proxmox's mp option set has no standalone "propagation" key; the propagation flag rides
+	// along in the same semicolon-separated mountoptions value as the other mount(8) flags.
+	var mountOptions string
+	if mount.Options != nil {
+		mountOptions = mount.Options.string()
+	}
+	if mount.Propagation != nil {
+		if mountOptions != "" {
+			mountOptions += ";"
+		}
+		mountOptions += *mount.Propagation
+	}
+	if mountOptions != "" {
+		settings += ",mountoptions=" + mountOptions
+	}
+	if mount.Quota != nil && *mount.Quota {
+		settings += ",quota=1"
+	}
+	if mount.ReadOnly != nil && *mount.ReadOnly {
+		settings += ",ro=1"
+	}
+	if mount.Replication != nil && !*mount.Replication {
+		settings += ",replicate=0"
+	}
+	if mount.Shared != nil && *mount.Shared {
+		settings += ",shared=1"
+	}
+	return
+}
+
+func (mount LxcMountPoint) Validate(current *LxcMountPoint) error {
+	if mount.ACL != nil {
+		if err := mount.ACL.Validate(); err != nil {
+			return err
+		}
+	}
+	if mount.Storage != nil && mount.HostPath != nil {
+		return errors.New(LxcMountPoint_Error_StorageAndHostPath)
+	}
+	if mount.HostPath != nil {
+		if err := ValidateVolumeHostDir(*mount.HostPath); err != nil {
+			return err
+		}
+	}
+	if mount.Propagation != nil {
+		if err := ValidateMountPropagation(*mount.Propagation); err != nil {
+			return err
+		}
+	}
+	if current == nil {
+		if mount.Storage == nil && mount.HostPath == nil {
+			return errors.New(LxcMountPoint_Error_NoVolumeDuringCreation)
+		}
+		if mount.Storage != nil && mount.SizeInKibibytes == nil {
+			return errors.New(LxcMountPoint_Error_NoSizeDuringCreation)
+		}
+		if mount.MountPath == nil {
+			return errors.New(LxcMountPoint_Error_NoMountPathDuringCreation)
+		}
+	}
+	if mount.SizeInKibibytes != nil {
+		if err := mount.SizeInKibibytes.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// MountPoints parses the mp0..mp255 keys Proxmox stores on an LXC config into LxcMountPoints.
+// It returns nil when the container has no additional mount points configured.
+func (raw RawConfigLXC) MountPoints() LxcMountPoints {
+	mounts := make(LxcMountPoints)
+	for i := 0; i < LxcMountPointCountMaximum; i++ {
+		v, isSet := raw[lxcApiKeyMountPointPrefix+strconv.Itoa(i)]
+		if !isSet {
+			continue
+		}
+		tmpString := strings.SplitN(v.(string), ",", 2)
+		var mount LxcMountPoint
+		var settings map[string]string
+		if index := strings.IndexRune(tmpString[0], ':'); index != -1 {
+			mount.Storage = util.Pointer(tmpString[0][:index])
+			mount.rawVolume = tmpString[0][index+1:]
+		} else {
+			mount.HostPath = util.Pointer(tmpString[0])
+		}
+		if len(tmpString) == 2 {
+			settings = splitStringOfSettings(tmpString[1])
+		}
+		if v, isSet := settings["mp"]; isSet {
+			mount.MountPath = util.Pointer(v)
+		}
+		if v, isSet := settings["size"]; isSet {
+			mount.SizeInKibibytes = util.Pointer(LxcMountSize(parseDiskSize(v)))
+		}
+		if v, isSet := settings["acl"]; isSet {
+			if v == "1" {
+				mount.ACL = util.Pointer(TriBoolTrue)
+			} else {
+				mount.ACL = util.Pointer(TriBoolFalse)
+			}
+		} else {
+			mount.ACL = util.Pointer(TriBoolNone)
+		}
+		if v, isSet := settings["backup"]; isSet {
+			mount.Backup = util.Pointer(v == "1")
+		} else {
+			mount.Backup = util.Pointer(false)
+		}
+		if v, isSet := settings["quota"]; isSet {
+			mount.Quota = util.Pointer(v == "1")
+		} else {
+			mount.Quota = util.Pointer(false)
+		}
+		if v, isSet := settings["ro"]; isSet {
+			mount.ReadOnly = util.Pointer(v == "1")
+		} else {
+			mount.ReadOnly = util.Pointer(false)
+		}
+		if v, isSet := settings["replicate"]; isSet {
+			mount.Replication = util.Pointer(v == "1")
+		} else {
+			mount.Replication = util.Pointer(true)
+		}
+		if v, isSet := settings["shared"]; isSet {
+			mount.Shared = util.Pointer(v == "1")
+		} else {
+			mount.Shared = util.Pointer(false)
+		}
+		if v, isSet := settings["mountoptions"]; isSet {
+			mount.Options = parseLxcBootMountOptions(v)
+			mount.Propagation = parseLxcMountPropagation(v)
+		}
+		mounts[uint8(i)] = mount
+	}
+	if len(mounts) == 0 {
+		return nil
+	}
+	return mounts
+}
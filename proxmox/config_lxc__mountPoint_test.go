@@ -0,0 +1,141 @@
+package proxmox
+
+import (
+	"testing"
+
+	"github.com/Telmate/proxmox-api-go/internal/util"
+)
+
+func TestRawConfigLXC_MountPoints_RoundTrip(t *testing.T) {
+	raw := RawConfigLXC{
+		"mp0": "local-lvm:8,acl=1,backup=1,mp=/mnt/data,mountoptions=discard;nosuid,quota=1,ro=1,replicate=0,shared=1",
+	}
+	mounts := raw.MountPoints()
+	mount, isSet := mounts[0]
+	if !isSet {
+		t.Fatalf("MountPoints() did not parse mp0")
+	}
+	got := *mount.Storage + ":" + mount.rawVolume + mount.string()
+	if got != raw["mp0"] {
+		t.Fatalf("round trip = %q, want %q", got, raw["mp0"])
+	}
+}
+
+func TestLxcMountPoints_Diff_NoOp(t *testing.T) {
+	mounts := LxcMountPoints{
+		0: {
+			Storage:   util.Pointer("local-lvm"),
+			MountPath: util.Pointer("/mnt/data"),
+			rawVolume: "8",
+		},
+	}
+	changed, params, err := mounts.Diff(mounts)
+	if err != nil {
+		t.Fatalf("Diff() unexpected error: %v", err)
+	}
+	if changed {
+		t.Fatalf("Diff() changed = true, want false; params = %v", params)
+	}
+	if len(params) != 0 {
+		t.Fatalf("Diff() params = %v, want empty", params)
+	}
+}
+
+func TestLxcMountPoint_Diff_SwitchesStorageToHostPath(t *testing.T) {
+	current := LxcMountPoint{
+		Storage:   util.Pointer("local-lvm"),
+		MountPath: util.Pointer("/mnt/data"),
+		rawVolume: "8",
+	}
+	config := LxcMountPoint{
+		HostPath: util.Pointer("/srv/data"),
+	}
+	changed, params := config.Diff(current, "mp0")
+	if !changed {
+		t.Fatalf("Diff() changed = false, want true")
+	}
+	want := "/srv/data,mp=/mnt/data"
+	if params["mp0"] != want {
+		t.Fatalf("Diff() params[\"mp0\"] = %v, want %q", params["mp0"], want)
+	}
+}
+
+func TestLxcMountPoint_Diff_SwitchesHostPathToStorage(t *testing.T) {
+	current := LxcMountPoint{
+		HostPath:  util.Pointer("/srv/data"),
+		MountPath: util.Pointer("/mnt/data"),
+	}
+	size := lxcMountSize_Minimum
+	config := LxcMountPoint{
+		Storage:         util.Pointer("local-lvm"),
+		SizeInKibibytes: &size,
+	}
+	changed, params := config.Diff(current, "mp0")
+	if !changed {
+		t.Fatalf("Diff() changed = false, want true")
+	}
+	want := "local-lvm:,mp=/mnt/data"
+	if params["mp0"] != want {
+		t.Fatalf("Diff() params[\"mp0\"] = %v, want %q", params["mp0"], want)
+	}
+}
+
+func TestValidateNoOverlappingHostPaths(t *testing.T) {
+	tests := []struct {
+		name    string
+		mounts  map[uint8]LxcMountPoint
+		wantErr string
+	}{
+		{
+			name: "no host paths",
+			mounts: map[uint8]LxcMountPoint{
+				0: {Storage: util.Pointer("local-lvm")},
+			},
+		},
+		{
+			name: "distinct host paths",
+			mounts: map[uint8]LxcMountPoint{
+				0: {HostPath: util.Pointer("/srv/data")},
+				1: {HostPath: util.Pointer("/srv/other")},
+			},
+		},
+		{
+			name: "identical host paths",
+			mounts: map[uint8]LxcMountPoint{
+				0: {HostPath: util.Pointer("/srv/data")},
+				1: {HostPath: util.Pointer("/srv/data")},
+			},
+			wantErr: "mount points mp0 and mp1 have overlapping host paths",
+		},
+		{
+			name: "nested host paths",
+			mounts: map[uint8]LxcMountPoint{
+				0: {HostPath: util.Pointer("/srv/data")},
+				1: {HostPath: util.Pointer("/srv/data/nested")},
+			},
+			wantErr: "mount points mp0 and mp1 have overlapping host paths",
+		},
+		{
+			name: "uncleaned but equal host paths",
+			mounts: map[uint8]LxcMountPoint{
+				0: {HostPath: util.Pointer("/srv/data/")},
+				1: {HostPath: util.Pointer("/srv/data")},
+			},
+			wantErr: "mount points mp0 and mp1 have overlapping host paths",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateNoOverlappingHostPaths(tt.mounts)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("validateNoOverlappingHostPaths() unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErr {
+				t.Fatalf("validateNoOverlappingHostPaths() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,150 @@
+package proxmox
+
+import (
+	"testing"
+
+	"github.com/Telmate/proxmox-api-go/internal/units"
+	"github.com/Telmate/proxmox-api-go/internal/util"
+)
+
+func TestLxcMountSizeFromString(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    LxcMountSize
+		wantErr bool
+	}{
+		{name: "whole gibibyte", input: "8G", want: LxcMountSize(8 * units.Gibibyte / units.Kibibyte)},
+		{name: "fractional gibibyte", input: "1.5G", want: LxcMountSize(1.5 * float64(units.Gibibyte) / float64(units.Kibibyte))},
+		{name: "explicit SI alias is decimal", input: "8GB", want: LxcMountSize(8 * units.Gigabyte / units.Kibibyte)},
+		{name: "minimum boundary value", input: "128M", want: lxcMountSize_Minimum},
+		{name: "bare number is kibibytes, not bytes", input: "8388608", want: LxcMountSize(8388608)},
+		{name: "invalid suffix", input: "8X", wantErr: true},
+		{name: "empty", input: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := LxcMountSizeFromString(tt.input)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("LxcMountSizeFromString(%q) expected an error, got none", tt.input)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("LxcMountSizeFromString(%q) unexpected error: %v", tt.input, err)
+			}
+			if got != tt.want {
+				t.Fatalf("LxcMountSizeFromString(%q) = %d, want %d", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLxcMountSizeValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		size    LxcMountSize
+		wantErr bool
+	}{
+		{name: "below minimum", size: lxcMountSize_Minimum - 1, wantErr: true},
+		{name: "at minimum", size: lxcMountSize_Minimum, wantErr: false},
+		{name: "above minimum", size: lxcMountSize_Minimum + 1, wantErr: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.size.Validate()
+			if tt.wantErr && err == nil {
+				t.Fatalf("LxcMountSize(%d).Validate() expected an error, got none", tt.size)
+			}
+			if !tt.wantErr && err != nil {
+				t.Fatalf("LxcMountSize(%d).Validate() unexpected error: %v", tt.size, err)
+			}
+		})
+	}
+}
+
+func TestLxcMountSizeMarshalText(t *testing.T) {
+	size := LxcMountSize(8 * units.Gibibyte / units.Kibibyte)
+	text, err := size.MarshalText()
+	if err != nil {
+		t.Fatalf("MarshalText() unexpected error: %v", err)
+	}
+	if string(text) != "8GiB" {
+		t.Fatalf("MarshalText() = %q, want %q", text, "8GiB")
+	}
+	var roundTripped LxcMountSize
+	if err := roundTripped.UnmarshalText(text); err != nil {
+		t.Fatalf("UnmarshalText(%q) unexpected error: %v", text, err)
+	}
+	if roundTripped != size {
+		t.Fatalf("round trip through %q produced %d, want %d", text, roundTripped, size)
+	}
+}
+
+func TestLxcBootMount_mapToApiCreate_keepsFractionalGibibyte(t *testing.T) {
+	size := LxcMountSize(1.5 * float64(units.Gibibyte) / float64(units.Kibibyte))
+	config := LxcBootMount{
+		Storage:         util.Pointer("local-lvm"),
+		SizeInKibibytes: &size,
+	}
+	got := config.mapToApiCreate()
+	want := "local-lvm:1.5"
+	if got != want {
+		t.Fatalf("mapToApiCreate() = %q, want %q", got, want)
+	}
+}
+
+func TestValidateVolumeHostDir(t *testing.T) {
+	tests := []struct {
+		name    string
+		path    string
+		wantErr string
+	}{
+		{name: "empty", path: "", wantErr: ValidateVolumeHostDir_Error_Empty},
+		{name: "relative path", path: "data/mnt", wantErr: ValidateVolumeHostDir_Error_NotAbsolute},
+		{name: "inside /var/lib/vz", path: "/var/lib/vz/images", wantErr: ValidateVolumeHostDir_Error_Managed},
+		{name: "is /var/lib/vz itself", path: "/var/lib/vz", wantErr: ValidateVolumeHostDir_Error_Managed},
+		{name: "inside /etc/pve", path: "/etc/pve/nodes", wantErr: ValidateVolumeHostDir_Error_Managed},
+		{name: "ordinary absolute path", path: "/srv/data", wantErr: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateVolumeHostDir(tt.path)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("ValidateVolumeHostDir(%q) unexpected error: %v", tt.path, err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErr {
+				t.Fatalf("ValidateVolumeHostDir(%q) error = %v, want %q", tt.path, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateMountPropagation(t *testing.T) {
+	tests := []struct {
+		name        string
+		propagation string
+		wantErr     bool
+	}{
+		{name: "shared", propagation: "shared", wantErr: false},
+		{name: "slave", propagation: "slave", wantErr: false},
+		{name: "private", propagation: "private", wantErr: false},
+		{name: "empty", propagation: "", wantErr: true},
+		{name: "unknown value", propagation: "rshared", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateMountPropagation(tt.propagation)
+			if tt.wantErr && (err == nil || err.Error() != ValidateMountPropagation_Error_Invalid) {
+				t.Fatalf("ValidateMountPropagation(%q) error = %v, want %q", tt.propagation, err, ValidateMountPropagation_Error_Invalid)
+			}
+			if !tt.wantErr && err != nil {
+				t.Fatalf("ValidateMountPropagation(%q) unexpected error: %v", tt.propagation, err)
+			}
+		})
+	}
+}